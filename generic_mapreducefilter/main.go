@@ -2,6 +2,8 @@ package main
 
 import (
 	"fmt"
+
+	"generic_mapreducefilter/pipeline"
 )
 
 // Functions place their type parameters after the function name
@@ -54,4 +56,18 @@ func main() {
 		return acc + val
 	})
 	fmt.Println(sum)
+
+	// The pipeline package does the same work lazily, in a single pass,
+	// and lets us chain the steps instead of naming each intermediate slice.
+	pipelineSum := pipeline.Reduce(
+		pipeline.Map(
+			pipeline.Filter(pipeline.FromSlice(words), func(s string) bool {
+				return s != "Potato"
+			}),
+			func(s string) int { return len(s) },
+		),
+		0,
+		func(acc, val int) int { return acc + val },
+	)
+	fmt.Println(pipelineSum)
 }
@@ -0,0 +1,164 @@
+// Package pipeline provides chainable, single-pass sequence operations
+// built on Go 1.23's iter.Seq. Unlike the slice-based Map/Reduce/Filter in
+// the parent package, these never materialize an intermediate slice, and
+// because they're free functions over iter.Seq rather than methods on a
+// generic slice type, they compose: Go doesn't allow a method to introduce
+// its own type parameters, so functionalSlice.Map[E any] can never exist,
+// but Map(in, f) can.
+package pipeline
+
+import "iter"
+
+// FromSlice returns an iterator over the elements of s.
+func FromSlice[T any](s []T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range s {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Range returns an iterator over the integers from lo up to (but not
+// including) hi, advancing by step. A negative step counts down from lo to
+// hi. A step of 0 yields nothing.
+func Range(lo, hi, step int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		switch {
+		case step > 0:
+			for i := lo; i < hi; i += step {
+				if !yield(i) {
+					return
+				}
+			}
+		case step < 0:
+			for i := lo; i > hi; i += step {
+				if !yield(i) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Map returns an iterator that applies f to each value of in.
+func Map[T1, T2 any](in iter.Seq[T1], f func(T1) T2) iter.Seq[T2] {
+	return func(yield func(T2) bool) {
+		for v := range in {
+			if !yield(f(v)) {
+				return
+			}
+		}
+	}
+}
+
+// Filter returns an iterator over the values of in for which p returns true.
+func Filter[T any](in iter.Seq[T], p func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range in {
+			if p(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Reduce consumes in, folding its values into init using f.
+func Reduce[T, A any](in iter.Seq[T], init A, f func(A, T) A) A {
+	acc := init
+	for v := range in {
+		acc = f(acc, v)
+	}
+	return acc
+}
+
+// Take returns an iterator over at most the first n values of in.
+func Take[T any](in iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if n <= 0 {
+			return
+		}
+		taken := 0
+		for v := range in {
+			if !yield(v) {
+				return
+			}
+			taken++
+			if taken >= n {
+				return
+			}
+		}
+	}
+}
+
+// Drop returns an iterator over the values of in after skipping the first n.
+func Drop[T any](in iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		skipped := 0
+		for v := range in {
+			if skipped < n {
+				skipped++
+				continue
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Chunk returns an iterator over successive, non-overlapping slices of up
+// to size values each, in order. The final chunk may be shorter than size.
+func Chunk[T any](in iter.Seq[T], size int) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		if size <= 0 {
+			return
+		}
+		var chunk []T
+		for v := range in {
+			chunk = append(chunk, v)
+			if len(chunk) == size {
+				if !yield(chunk) {
+					return
+				}
+				chunk = nil
+			}
+		}
+		if len(chunk) > 0 {
+			yield(chunk)
+		}
+	}
+}
+
+// FlatMap applies f to each value of in and flattens the resulting
+// iterators into a single sequence.
+func FlatMap[T1, T2 any](in iter.Seq[T1], f func(T1) iter.Seq[T2]) iter.Seq[T2] {
+	return func(yield func(T2) bool) {
+		for v := range in {
+			for w := range f(v) {
+				if !yield(w) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Zip pairs up values from a and b in lock step, stopping as soon as
+// either sequence is exhausted.
+func Zip[T1, T2 any](a iter.Seq[T1], b iter.Seq[T2]) iter.Seq2[T1, T2] {
+	return func(yield func(T1, T2) bool) {
+		next, stop := iter.Pull(b)
+		defer stop()
+		for v := range a {
+			w, ok := next()
+			if !ok {
+				return
+			}
+			if !yield(v, w) {
+				return
+			}
+		}
+	}
+}
@@ -63,4 +63,12 @@ func main() {
 	s.Push(30)
 	fmt.Println(s.Contains(10))
 	fmt.Println(s.Contains(5))
+
+	// SyncStack is safe to share across goroutines and can block a
+	// consumer until a producer pushes a value or the context is done.
+	syncStack := NewSyncStack[int](2)
+	fmt.Println(syncStack.Push(1))
+	fmt.Println(syncStack.Push(2))
+	fmt.Println(syncStack.Push(3)) // capacity error
+	fmt.Println(syncStack.Contains(1))
 }
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// SyncStack is a bounded, thread-safe variant of Stack[T]. Push/Pop take
+// the write lock, Contains takes the read lock, and PopWait blocks until a
+// value is available or the supplied context is done, turning the stack
+// into a bounded work queue.
+type SyncStack[T comparable] struct {
+	mu       sync.RWMutex
+	notEmpty *sync.Cond
+	vals     []T
+	capacity int
+}
+
+// NewSyncStack returns a SyncStack that holds at most capacity values.
+func NewSyncStack[T comparable](capacity int) *SyncStack[T] {
+	s := &SyncStack[T]{capacity: capacity}
+	s.notEmpty = sync.NewCond(&s.mu)
+	return s
+}
+
+// Push adds val to the stack, or returns an error if the stack is already
+// at capacity.
+func (s *SyncStack[T]) Push(val T) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.vals) >= s.capacity {
+		return fmt.Errorf("syncstack: full at capacity %d", s.capacity)
+	}
+	s.vals = append(s.vals, val)
+	s.notEmpty.Signal()
+	return nil
+}
+
+func (s *SyncStack[T]) Pop() (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pop()
+}
+
+func (s *SyncStack[T]) pop() (T, bool) {
+	if len(s.vals) == 0 {
+		var zero T
+		return zero, false
+	}
+	top := s.vals[len(s.vals)-1]
+	s.vals = s.vals[:len(s.vals)-1]
+	return top, true
+}
+
+func (s *SyncStack[T]) Contains(val T) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, v := range s.vals {
+		if v == val {
+			return true
+		}
+	}
+	return false
+}
+
+// PopWait blocks until a value is available to pop or ctx is done,
+// whichever happens first. sync.Cond has no notion of a context, so a
+// helper goroutine wakes up the waiter when ctx is canceled.
+func (s *SyncStack[T]) PopWait(ctx context.Context) (T, error) {
+	stopWatching := make(chan struct{})
+	defer close(stopWatching)
+	go func() {
+		select {
+		case <-ctx.Done():
+			s.mu.Lock()
+			s.notEmpty.Broadcast()
+			s.mu.Unlock()
+		case <-stopWatching:
+		}
+	}()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for len(s.vals) == 0 {
+		if err := ctx.Err(); err != nil {
+			var zero T
+			return zero, err
+		}
+		s.notEmpty.Wait()
+	}
+	v, _ := s.pop()
+	return v, nil
+}
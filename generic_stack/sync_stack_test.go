@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSyncStackPushFullReturnsError(t *testing.T) {
+	s := NewSyncStack[int](2)
+	if err := s.Push(1); err != nil {
+		t.Fatalf("Push(1) = %v, want nil", err)
+	}
+	if err := s.Push(2); err != nil {
+		t.Fatalf("Push(2) = %v, want nil", err)
+	}
+	if err := s.Push(3); err == nil {
+		t.Fatal("Push on full stack = nil error, want error")
+	}
+}
+
+func TestSyncStackPopWait(t *testing.T) {
+	s := NewSyncStack[int](1)
+	ctx := context.Background()
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		s.Push(42)
+	}()
+
+	v, err := s.PopWait(ctx)
+	if err != nil {
+		t.Fatalf("PopWait() error = %v, want nil", err)
+	}
+	if v != 42 {
+		t.Fatalf("PopWait() = %d, want 42", v)
+	}
+}
+
+func TestSyncStackPopWaitContextDone(t *testing.T) {
+	s := NewSyncStack[int](1)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := s.PopWait(ctx); err == nil {
+		t.Fatal("PopWait on empty, canceled context = nil error, want error")
+	}
+}
+
+// chanStack is a minimal channel-backed bounded stack used only to give the
+// benchmarks below a comparison point against SyncStack's RWMutex/Cond
+// implementation. A channel pops values in FIFO order, not LIFO, but for
+// Push/Pop throughput that doesn't matter.
+type chanStack[T any] chan T
+
+func newChanStack[T any](capacity int) chanStack[T] {
+	return make(chanStack[T], capacity)
+}
+
+func (c chanStack[T]) Push(v T) error {
+	select {
+	case c <- v:
+		return nil
+	default:
+		return errFull
+	}
+}
+
+func (c chanStack[T]) Pop() (T, bool) {
+	select {
+	case v := <-c:
+		return v, true
+	default:
+		var zero T
+		return zero, false
+	}
+}
+
+var errFull = errors.New("chanStack: full")
+
+func BenchmarkSyncStackPushPop(b *testing.B) {
+	s := NewSyncStack[int](1024)
+	var wg sync.WaitGroup
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		wg.Add(1)
+		go func(v int) {
+			defer wg.Done()
+			s.Push(v)
+			s.Pop()
+		}(i)
+	}
+	wg.Wait()
+}
+
+func BenchmarkChanStackPushPop(b *testing.B) {
+	s := newChanStack[int](1024)
+	var wg sync.WaitGroup
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		wg.Add(1)
+		go func(v int) {
+			defer wg.Done()
+			s.Push(v)
+			s.Pop()
+		}(i)
+	}
+	wg.Wait()
+}
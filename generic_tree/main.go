@@ -2,7 +2,9 @@ package main
 
 import (
 	"fmt"
+	"iter"
 	"strings"
+	"sync"
 )
 
 func main() {
@@ -41,11 +43,15 @@ type OrderableFunc[T any] func(t1, t2 T) int
 type Tree[T any] struct {
 	f    OrderableFunc[T]
 	root *Node[T]
+	size int
 }
 
+// Node keeps an AVL height so Add/Contains/Remove stay O(log n) even when
+// values arrive in sorted order, instead of degenerating into a linked list.
 type Node[T any] struct {
 	val         T
 	left, right *Node[T]
+	height      int8
 }
 
 func NewTree[T any](f OrderableFunc[T]) *Tree[T] {
@@ -56,26 +62,121 @@ func NewTree[T any](f OrderableFunc[T]) *Tree[T] {
 
 // Tree ’s methods are very simple, because they just call Node to do all the real work:
 func (t *Tree[T]) Add(v T) {
-	t.root = t.root.Add(t.f, v)
+	var inserted bool
+	t.root, inserted = t.root.Add(t.f, v)
+	if inserted {
+		t.size++
+	}
 }
 
 func (t *Tree[T]) Contains(v T) bool {
 	return t.root.Contains(t.f, v)
 }
 
+// Remove deletes v from the tree, rebalancing on the way back up the
+// recursion stack, and reports whether v was found.
+func (t *Tree[T]) Remove(v T) bool {
+	var removed bool
+	t.root, removed = t.root.Remove(t.f, v)
+	if removed {
+		t.size--
+	}
+	return removed
+}
+
+// Len reports the number of values stored in the tree.
+func (t *Tree[T]) Len() int {
+	return t.size
+}
+
+// Min returns the smallest value in the tree and reports whether the tree
+// is non-empty.
+func (t *Tree[T]) Min() (T, bool) {
+	if t.root == nil {
+		var zero T
+		return zero, false
+	}
+	return t.root.min(), true
+}
+
+// Max returns the largest value in the tree and reports whether the tree
+// is non-empty.
+func (t *Tree[T]) Max() (T, bool) {
+	if t.root == nil {
+		var zero T
+		return zero, false
+	}
+	return t.root.max(), true
+}
+
+// InOrder returns an iterator over the tree's values in ascending order, as
+// defined by the tree's OrderableFunc.
+func (t *Tree[T]) InOrder() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		t.root.inOrder(yield)
+	}
+}
+
+// Range returns an iterator over the values v in the tree for which
+// f(v, lo) >= 0 and f(v, hi) <= 0, visited in ascending order. It prunes
+// subtrees that fall entirely outside [lo, hi] instead of walking the whole
+// tree.
+func (t *Tree[T]) Range(lo, hi T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		t.root.rangeSeq(t.f, lo, hi, yield)
+	}
+}
+
+// SyncTree wraps a Tree[T] with a sync.RWMutex, so it can be shared across
+// goroutines: Add and Remove take the write lock, Contains and the
+// read-only queries take the read lock.
+type SyncTree[T any] struct {
+	mu   sync.RWMutex
+	tree *Tree[T]
+}
+
+func NewSyncTree[T any](f OrderableFunc[T]) *SyncTree[T] {
+	return &SyncTree[T]{tree: NewTree(f)}
+}
+
+func (t *SyncTree[T]) Add(v T) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.tree.Add(v)
+}
+
+func (t *SyncTree[T]) Contains(v T) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.tree.Contains(v)
+}
+
+func (t *SyncTree[T]) Remove(v T) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.tree.Remove(v)
+}
+
+func (t *SyncTree[T]) Len() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.tree.Len()
+}
+
 // The Add and Contains methods on Node are very similar to what we’ve seen before (see generic_stack directory).
 // The only difference is that the function we are using to order our elements is passed in:
-func (n *Node[T]) Add(f OrderableFunc[T], v T) *Node[T] {
+func (n *Node[T]) Add(f OrderableFunc[T], v T) (*Node[T], bool) {
 	if n == nil {
-		return &Node[T]{val: v}
+		return &Node[T]{val: v, height: 1}, true
 	}
+	var inserted bool
 	switch r := f(v, n.val); {
 	case r <= -1:
-		n.left = n.left.Add(f, v)
+		n.left, inserted = n.left.Add(f, v)
 	case r >= 1:
-		n.right = n.right.Add(f, v)
+		n.right, inserted = n.right.Add(f, v)
 	}
-	return n
+	return n.rebalance(), inserted
 }
 
 func (n *Node[T]) Contains(f OrderableFunc[T], v T) bool {
@@ -91,6 +192,159 @@ func (n *Node[T]) Contains(f OrderableFunc[T], v T) bool {
 	return true
 }
 
+// Remove deletes v from the subtree rooted at n, returning the new subtree
+// root and whether v was found. A node with two children is removed by
+// copying up its in-order successor (the minimum of the right subtree) and
+// then deleting that successor instead.
+func (n *Node[T]) Remove(f OrderableFunc[T], v T) (*Node[T], bool) {
+	if n == nil {
+		return nil, false
+	}
+
+	var removed bool
+	switch r := f(v, n.val); {
+	case r <= -1:
+		n.left, removed = n.left.Remove(f, v)
+	case r >= 1:
+		n.right, removed = n.right.Remove(f, v)
+	default:
+		removed = true
+		switch {
+		case n.left == nil:
+			return n.right, true
+		case n.right == nil:
+			return n.left, true
+		default:
+			succ := n.right.min()
+			n.val = succ
+			n.right, _ = n.right.Remove(f, succ)
+		}
+	}
+	return n.rebalance(), removed
+}
+
+func (n *Node[T]) min() T {
+	for n.left != nil {
+		n = n.left
+	}
+	return n.val
+}
+
+func (n *Node[T]) max() T {
+	for n.right != nil {
+		n = n.right
+	}
+	return n.val
+}
+
+// inOrder walks the subtree in ascending order, stopping early if yield
+// returns false.
+func (n *Node[T]) inOrder(yield func(T) bool) bool {
+	if n == nil {
+		return true
+	}
+	if !n.left.inOrder(yield) {
+		return false
+	}
+	if !yield(n.val) {
+		return false
+	}
+	return n.right.inOrder(yield)
+}
+
+// rangeSeq walks the subtree in ascending order, visiting only values v for
+// which f(v, lo) >= 0 and f(v, hi) <= 0. It skips the left subtree when n
+// is already below lo, and the right subtree when n is already above hi, so
+// it never descends into a subtree that lies entirely outside the range.
+func (n *Node[T]) rangeSeq(f OrderableFunc[T], lo, hi T, yield func(T) bool) bool {
+	if n == nil {
+		return true
+	}
+	if f(n.val, lo) >= 0 {
+		if !n.left.rangeSeq(f, lo, hi, yield) {
+			return false
+		}
+	}
+	if f(n.val, lo) >= 0 && f(n.val, hi) <= 0 {
+		if !yield(n.val) {
+			return false
+		}
+	}
+	if f(n.val, hi) <= 0 {
+		if !n.right.rangeSeq(f, lo, hi, yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// rebalance recomputes n's height and, if n has become unbalanced, performs
+// the standard AVL rotation(s) so that no two sibling subtrees differ in
+// height by more than one.
+func (n *Node[T]) rebalance() *Node[T] {
+	if n == nil {
+		return nil
+	}
+	n.updateHeight()
+
+	switch bf := n.balanceFactor(); {
+	case bf > 1:
+		if n.left.balanceFactor() < 0 {
+			n.left = n.left.rotateLeft()
+		}
+		return n.rotateRight()
+	case bf < -1:
+		if n.right.balanceFactor() > 0 {
+			n.right = n.right.rotateRight()
+		}
+		return n.rotateLeft()
+	}
+	return n
+}
+
+func (n *Node[T]) rotateRight() *Node[T] {
+	l := n.left
+	n.left = l.right
+	l.right = n
+	n.updateHeight()
+	l.updateHeight()
+	return l
+}
+
+func (n *Node[T]) rotateLeft() *Node[T] {
+	r := n.right
+	n.right = r.left
+	r.left = n
+	n.updateHeight()
+	r.updateHeight()
+	return r
+}
+
+func (n *Node[T]) updateHeight() {
+	n.height = 1 + max(n.left.nodeHeight(), n.right.nodeHeight())
+}
+
+func (n *Node[T]) balanceFactor() int8 {
+	if n == nil {
+		return 0
+	}
+	return n.left.nodeHeight() - n.right.nodeHeight()
+}
+
+func (n *Node[T]) nodeHeight() int8 {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func max(a, b int8) int8 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
 // Now we need a function that matches the OrderedFunc definition.
 // By taking advantage of BuiltInOrdered,
 // we can write a single function that supports any primitive type:
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"math"
+	"sync"
+	"testing"
+)
+
+// TestAddKeepsTreeBalanced inserts values in already-sorted order, the
+// worst case for a naive BST, and checks the resulting height stays
+// O(log n) instead of degenerating into a linked list.
+func TestAddKeepsTreeBalanced(t *testing.T) {
+	tree := NewTree(BuiltInOrderable[int])
+	const n = 10_000
+	for i := 0; i < n; i++ {
+		tree.Add(i)
+	}
+
+	got := tree.root.nodeHeight()
+	max := int8(2 * math.Log2(float64(n+1)))
+	if got > max {
+		t.Fatalf("tree height %d exceeds O(log n) bound %d for n=%d", got, max, n)
+	}
+
+	for _, v := range []int{0, n / 2, n - 1} {
+		if !tree.Contains(v) {
+			t.Errorf("Contains(%d) = false, want true", v)
+		}
+	}
+	if tree.Contains(n) {
+		t.Errorf("Contains(%d) = true, want false", n)
+	}
+}
+
+func TestRemove(t *testing.T) {
+	tree := NewTree(BuiltInOrderable[int])
+	for _, v := range []int{10, 30, 15, 5, 20, 40, 1} {
+		tree.Add(v)
+	}
+
+	if !tree.Remove(15) {
+		t.Fatal("Remove(15) = false, want true")
+	}
+	if tree.Contains(15) {
+		t.Error("Contains(15) = true after removal, want false")
+	}
+	for _, v := range []int{10, 30, 5, 20, 40, 1} {
+		if !tree.Contains(v) {
+			t.Errorf("Contains(%d) = false after unrelated removal, want true", v)
+		}
+	}
+
+	if tree.Remove(999) {
+		t.Error("Remove(999) = true, want false for missing value")
+	}
+}
+
+func TestInOrder(t *testing.T) {
+	tree := NewTree(BuiltInOrderable[int])
+	for _, v := range []int{5, 3, 8, 1, 4, 7, 9} {
+		tree.Add(v)
+	}
+
+	var got []int
+	for v := range tree.InOrder() {
+		got = append(got, v)
+	}
+	want := []int{1, 3, 4, 5, 7, 8, 9}
+	if len(got) != len(want) {
+		t.Fatalf("InOrder() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("InOrder() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestMinMaxLen(t *testing.T) {
+	tree := NewTree(BuiltInOrderable[int])
+	if _, ok := tree.Min(); ok {
+		t.Error("Min() on empty tree reported ok=true")
+	}
+	for _, v := range []int{5, 3, 8, 1, 9} {
+		tree.Add(v)
+	}
+	if got, _ := tree.Min(); got != 1 {
+		t.Errorf("Min() = %d, want 1", got)
+	}
+	if got, _ := tree.Max(); got != 9 {
+		t.Errorf("Max() = %d, want 9", got)
+	}
+	if got := tree.Len(); got != 5 {
+		t.Errorf("Len() = %d, want 5", got)
+	}
+}
+
+// countingComparator wraps an OrderableFunc and counts how many times it is
+// called, so a test can tell whether Range actually pruned subtrees instead
+// of just filtering a full walk.
+func countingComparator[T any](f OrderableFunc[T]) (OrderableFunc[T], *int) {
+	calls := 0
+	return func(t1, t2 T) int {
+		calls++
+		return f(t1, t2)
+	}, &calls
+}
+
+func TestRangePrunesSubtrees(t *testing.T) {
+	cmp, calls := countingComparator(BuiltInOrderable[int])
+	tree := NewTree(cmp)
+	for i := 0; i < 100; i++ {
+		tree.Add(i)
+	}
+	*calls = 0
+
+	var got []int
+	for v := range tree.Range(40, 45) {
+		got = append(got, v)
+	}
+	want := []int{40, 41, 42, 43, 44, 45}
+	if len(got) != len(want) {
+		t.Fatalf("Range(40, 45) = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Range(40, 45) = %v, want %v", got, want)
+		}
+	}
+
+	// A full in-order walk would invoke the comparator at least twice per
+	// node (once per bound check). Pruned subtrees should keep the call
+	// count well under that, even though the tree holds 100 values.
+	if *calls >= 200 {
+		t.Errorf("Range visited too many nodes: comparator called %d times, want < 200", *calls)
+	}
+}
+
+func TestSyncTreeConcurrentAdd(t *testing.T) {
+	tree := NewSyncTree(BuiltInOrderable[int])
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(v int) {
+			defer wg.Done()
+			tree.Add(v)
+		}(i)
+	}
+	wg.Wait()
+
+	if got := tree.Len(); got != 100 {
+		t.Errorf("Len() = %d, want 100", got)
+	}
+	for _, v := range []int{0, 50, 99} {
+		if !tree.Contains(v) {
+			t.Errorf("Contains(%d) = false, want true", v)
+		}
+	}
+	if !tree.Remove(50) {
+		t.Fatal("Remove(50) = false, want true")
+	}
+	if tree.Contains(50) {
+		t.Error("Contains(50) = true after Remove, want false")
+	}
+}
@@ -0,0 +1,277 @@
+// Package books holds the HTTP handlers and data access for the bookstore
+// sample, backed by Postgres via sqlx.
+package books
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+
+	"routing_with_tpl_v_organize/middleware"
+)
+
+// Book mirrors a row of the books table. The db tags let sqlx populate it
+// directly with StructScan, so a column reorder in the schema no longer
+// silently corrupts data the way positional rows.Scan did.
+type Book struct {
+	Isbn   string  `db:"isbn"`
+	Title  string  `db:"title"`
+	Author string  `db:"author"`
+	Price  float32 `db:"price"`
+}
+
+// Repository wraps a *sqlx.DB so handlers query through a typed API
+// instead of reaching into a global connection.
+type Repository struct {
+	db *sqlx.DB
+}
+
+func NewRepository(db *sqlx.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// WithTx runs fn against a fresh transaction on the repository's
+// connection, committing on success and rolling back on error or panic.
+// It gives tests (and any caller outside an HTTP request) the same
+// transactional semantics that middleware.WithTx gives handlers.
+func (r *Repository) WithTx(ctx context.Context, fn func(middleware.Querier) error) (err error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	err = fn(tx)
+	return err
+}
+
+// queryAll and queryByISBN use sqlx's StructScan (via SelectContext and
+// GetContext), so the db struct tags on Book keep driving the column
+// mapping instead of a hand-maintained positional Scan.
+
+func queryAll(ctx context.Context, q middleware.Querier) ([]Book, error) {
+	bks := make([]Book, 0)
+	err := q.SelectContext(ctx, &bks, "SELECT isbn, title, author, price FROM books")
+	return bks, err
+}
+
+func queryByISBN(ctx context.Context, q middleware.Querier, isbn string) (Book, error) {
+	var bk Book
+	err := q.GetContext(ctx, &bk, "SELECT isbn, title, author, price FROM books WHERE isbn=$1", isbn)
+	return bk, err
+}
+
+func execCreate(ctx context.Context, q middleware.Querier, bk Book) error {
+	_, err := q.NamedExecContext(ctx,
+		"INSERT INTO books (isbn, title, author, price) VALUES (:isbn, :title, :author, :price)",
+		bk,
+	)
+	return err
+}
+
+func execUpdate(ctx context.Context, q middleware.Querier, bk Book) error {
+	_, err := q.NamedExecContext(ctx,
+		"UPDATE books SET title=:title, author=:author, price=:price WHERE isbn=:isbn",
+		bk,
+	)
+	return err
+}
+
+func execDelete(ctx context.Context, q middleware.Querier, isbn string) error {
+	_, err := q.ExecContext(ctx, "DELETE FROM books WHERE isbn=$1", isbn)
+	return err
+}
+
+// Handlers holds the dependencies the HTTP handlers need. Constructing one
+// with NewHandlers and registering its methods keeps the repository an
+// injected dependency instead of a package-level global.
+type Handlers struct {
+	repo *Repository
+}
+
+func NewHandlers(repo *Repository) *Handlers {
+	return &Handlers{repo: repo}
+}
+
+// querier returns the request-scoped transaction middleware.WithTx stashed
+// on ctx, falling back to the handlers' own repository connection when
+// running without that middleware (e.g. in tests).
+func (h *Handlers) querier(ctx context.Context) middleware.Querier {
+	if q, ok := middleware.TxFromContext(ctx); ok {
+		return q
+	}
+	return h.repo.db
+}
+
+func (h *Handlers) Index(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, http.StatusText(405), http.StatusMethodNotAllowed)
+		return
+	}
+
+	bks, err := queryAll(r.Context(), h.querier(r.Context()))
+	if err != nil {
+		http.Error(w, http.StatusText(500), http.StatusInternalServerError)
+		return
+	}
+
+	for _, bk := range bks {
+		fmt.Fprintf(w, "%s, %s, %s, $%.2f\n", bk.Isbn, bk.Title, bk.Author, bk.Price)
+	}
+}
+
+func (h *Handlers) Show(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, http.StatusText(405), http.StatusMethodNotAllowed)
+		return
+	}
+
+	isbn := r.FormValue("isbn")
+	if isbn == "" {
+		http.Error(w, http.StatusText(400), http.StatusBadRequest)
+		return
+	}
+
+	bk, err := queryByISBN(r.Context(), h.querier(r.Context()), isbn)
+	switch {
+	case err == sql.ErrNoRows:
+		http.NotFound(w, r)
+		return
+	case err != nil:
+		http.Error(w, http.StatusText(500), http.StatusInternalServerError)
+		return
+	}
+
+	fmt.Fprintf(w, "%s, %s, %s, $%.2f\n", bk.Isbn, bk.Title, bk.Author, bk.Price)
+}
+
+func (h *Handlers) CreateForm(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, http.StatusText(405), http.StatusMethodNotAllowed)
+		return
+	}
+	fmt.Fprint(w, `<form method="POST" action="/books/create/process">
+		<input name="isbn" placeholder="isbn">
+		<input name="title" placeholder="title">
+		<input name="author" placeholder="author">
+		<input name="price" placeholder="price">
+		<button type="submit">Create</button>
+	</form>`)
+}
+
+func (h *Handlers) CreateProcess(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, http.StatusText(405), http.StatusMethodNotAllowed)
+		return
+	}
+
+	bk, err := bookFromForm(r)
+	if err != nil {
+		http.Error(w, http.StatusText(400), http.StatusBadRequest)
+		return
+	}
+
+	if err := execCreate(r.Context(), h.querier(r.Context()), bk); err != nil {
+		http.Error(w, http.StatusText(500), http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/books", http.StatusSeeOther)
+}
+
+func (h *Handlers) UpdateForm(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, http.StatusText(405), http.StatusMethodNotAllowed)
+		return
+	}
+
+	isbn := r.FormValue("isbn")
+	if isbn == "" {
+		http.Error(w, http.StatusText(400), http.StatusBadRequest)
+		return
+	}
+
+	bk, err := queryByISBN(r.Context(), h.querier(r.Context()), isbn)
+	switch {
+	case err == sql.ErrNoRows:
+		http.NotFound(w, r)
+		return
+	case err != nil:
+		http.Error(w, http.StatusText(500), http.StatusInternalServerError)
+		return
+	}
+
+	fmt.Fprintf(w, `<form method="POST" action="/books/update/process">
+		<input type="hidden" name="isbn" value="%s">
+		<input name="title" value="%s">
+		<input name="author" value="%s">
+		<input name="price" value="%.2f">
+		<button type="submit">Update</button>
+	</form>`, bk.Isbn, bk.Title, bk.Author, bk.Price)
+}
+
+func (h *Handlers) UpdateProcess(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, http.StatusText(405), http.StatusMethodNotAllowed)
+		return
+	}
+
+	bk, err := bookFromForm(r)
+	if err != nil {
+		http.Error(w, http.StatusText(400), http.StatusBadRequest)
+		return
+	}
+
+	if err := execUpdate(r.Context(), h.querier(r.Context()), bk); err != nil {
+		http.Error(w, http.StatusText(500), http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/books", http.StatusSeeOther)
+}
+
+func (h *Handlers) DeleteProcess(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, http.StatusText(405), http.StatusMethodNotAllowed)
+		return
+	}
+
+	isbn := r.FormValue("isbn")
+	if isbn == "" {
+		http.Error(w, http.StatusText(400), http.StatusBadRequest)
+		return
+	}
+
+	if err := execDelete(r.Context(), h.querier(r.Context()), isbn); err != nil {
+		http.Error(w, http.StatusText(500), http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/books", http.StatusSeeOther)
+}
+
+func bookFromForm(r *http.Request) (Book, error) {
+	var price float32
+	if _, err := fmt.Sscanf(r.FormValue("price"), "%f", &price); err != nil {
+		return Book{}, err
+	}
+	return Book{
+		Isbn:   r.FormValue("isbn"),
+		Title:  r.FormValue("title"),
+		Author: r.FormValue("author"),
+		Price:  price,
+	}, nil
+}
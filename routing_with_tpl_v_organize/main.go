@@ -2,19 +2,26 @@ package main
 
 import (
 	"net/http"
+
 	"routing_with_tpl_v_organize/books"
+	"routing_with_tpl_v_organize/config"
+	"routing_with_tpl_v_organize/middleware"
 )
 
 func main() {
-	http.HandleFunc("/", index)
-	http.HandleFunc("/books", books.Index)
-	http.HandleFunc("/books/show", books.Show)
-	http.HandleFunc("/books/create", books.CreateForm)
-	http.HandleFunc("/books/create/process", books.CreateProcess)
-	http.HandleFunc("/books/update", books.UpdateForm)
-	http.HandleFunc("/books/update/process", books.UpdateProcess)
-	http.HandleFunc("/books/delete/process", books.DeleteProcess)
-	http.ListenAndServe(":8080", nil)
+	h := books.NewHandlers(books.NewRepository(config.DB))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", index)
+	mux.HandleFunc("/books", h.Index)
+	mux.HandleFunc("/books/show", h.Show)
+	mux.HandleFunc("/books/create", h.CreateForm)
+	mux.HandleFunc("/books/create/process", h.CreateProcess)
+	mux.HandleFunc("/books/update", h.UpdateForm)
+	mux.HandleFunc("/books/update/process", h.UpdateProcess)
+	mux.HandleFunc("/books/delete/process", h.DeleteProcess)
+
+	http.ListenAndServe(":8080", middleware.WithTx(config.DB)(mux))
 }
 
 func index(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,78 @@
+// Package middleware holds cross-cutting HTTP middleware for the
+// bookstore sample.
+package middleware
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Querier is the subset of *sqlx.DB and *sqlx.Tx that handlers need to run
+// queries. It's built on sqlx's Get/Select/NamedExec so handlers keep
+// StructScan and named parameters whether they're running against the
+// plain connection or a request-scoped transaction.
+type Querier interface {
+	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error)
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+type txKey struct{}
+
+// TxFromContext returns the Querier stashed in ctx by WithTx, if any.
+func TxFromContext(ctx context.Context) (Querier, bool) {
+	q, ok := ctx.Value(txKey{}).(Querier)
+	return q, ok
+}
+
+// WithTx opens a *sqlx.Tx per request and stores it in the request's
+// context so handlers can read it with TxFromContext. The transaction is
+// committed when the handler's status is below 400 (a 2xx, or a 3xx such as
+// the redirect the books handlers send after a write), and rolled back if
+// the handler panics or writes a >=400 status.
+func WithTx(db *sqlx.DB) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tx, err := db.BeginTxx(r.Context(), nil)
+			if err != nil {
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				return
+			}
+
+			sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+			ctx := context.WithValue(r.Context(), txKey{}, tx)
+
+			defer func() {
+				if p := recover(); p != nil {
+					tx.Rollback()
+					panic(p)
+				}
+				if sw.status >= 400 {
+					tx.Rollback()
+					return
+				}
+				if err := tx.Commit(); err != nil {
+					http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				}
+			}()
+
+			next.ServeHTTP(sw, r.WithContext(ctx))
+		})
+	}
+}
+
+// statusWriter wraps a ResponseWriter so WithTx can observe the status
+// code the handler wrote, which it needs to decide commit vs. rollback.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}